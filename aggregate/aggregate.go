@@ -0,0 +1,113 @@
+// Package aggregate combines observations from multiple providers into
+// a single summary, one statistic per field.
+package aggregate
+
+import (
+	"sort"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// Func is an aggregation strategy applied independently to each
+// numeric field of a set of observations.
+type Func func(values []float64) float64
+
+// Average returns the arithmetic mean.
+func Average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Min returns the smallest value.
+func Min(values []float64) float64 {
+	return extreme(values, func(a, b float64) bool { return a < b })
+}
+
+// Max returns the largest value.
+func Max(values []float64) float64 {
+	return extreme(values, func(a, b float64) bool { return a > b })
+}
+
+// Median returns the middle value (or the mean of the two middle
+// values for an even-sized input).
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+func extreme(values []float64, better func(a, b float64) bool) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	best := values[0]
+	for _, v := range values[1:] {
+		if better(v, best) {
+			best = v
+		}
+	}
+	return best
+}
+
+// ByName looks up an aggregation function by its CLI name (average,
+// min, max, median). It defaults to Average for unknown names.
+func ByName(name string) Func {
+	switch name {
+	case "min":
+		return Min
+	case "max":
+		return Max
+	case "median":
+		return Median
+	default:
+		return Average
+	}
+}
+
+// Observation applies fn to each numeric field across obs
+// independently, returning a synthetic Observation carrying the
+// aggregated values. Non-numeric fields (description, icon, alerts)
+// are taken from the first observation.
+func Observation(obs []providers.Observation, fn Func) providers.Observation {
+	if len(obs) == 0 {
+		return providers.Observation{}
+	}
+
+	result := obs[0]
+	result.Provider = "aggregate"
+
+	result.TempC = fn(collect(obs, func(o providers.Observation) float64 { return o.TempC }))
+	result.FeelsLikeC = fn(collect(obs, func(o providers.Observation) float64 { return o.FeelsLikeC }))
+	result.PressureHPa = fn(collect(obs, func(o providers.Observation) float64 { return o.PressureHPa }))
+	result.Wind.SpeedMPS = fn(collect(obs, func(o providers.Observation) float64 { return o.Wind.SpeedMPS }))
+	result.Wind.DirDeg = fn(collect(obs, func(o providers.Observation) float64 { return o.Wind.DirDeg }))
+	result.Wind.GustMPS = fn(collect(obs, func(o providers.Observation) float64 { return o.Wind.GustMPS }))
+	result.Visibility = fn(collect(obs, func(o providers.Observation) float64 { return o.Visibility }))
+	result.RainMM = fn(collect(obs, func(o providers.Observation) float64 { return o.RainMM }))
+	result.SnowMM = fn(collect(obs, func(o providers.Observation) float64 { return o.SnowMM }))
+	result.Humidity = int(fn(collect(obs, func(o providers.Observation) float64 { return float64(o.Humidity) })))
+	result.CloudCover = int(fn(collect(obs, func(o providers.Observation) float64 { return float64(o.CloudCover) })))
+
+	return result
+}
+
+func collect(obs []providers.Observation, get func(providers.Observation) float64) []float64 {
+	values := make([]float64, len(obs))
+	for i, o := range obs {
+		values[i] = get(o)
+	}
+	return values
+}
@@ -0,0 +1,52 @@
+// Package cache stores per-city provider observations with per-entry
+// freshness, behind a pluggable Store so the daemon or CLI can pick a
+// JSON file, BoltDB, or Redis as the backing store.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// errNegative is wrapped into the error SWR.Get returns when it's
+// serving a remembered failure instead of calling refresh again.
+var errNegative = errors.New("cached negative result")
+
+// Entry is everything cached for one city: the per-provider payloads
+// as of FetchedAt, or Negative if the city is known to fail lookups
+// (so we don't hammer a provider that just 404'd on it).
+type Entry struct {
+	FetchedAt time.Time                        `json:"fetched_at"`
+	Payloads  map[string]providers.Observation `json:"payloads"`
+	Negative  bool                             `json:"negative,omitempty"`
+}
+
+// Fresh reports whether e is within ttl of now.
+func (e Entry) Fresh(ttl time.Duration, now time.Time) bool {
+	return now.Sub(e.FetchedAt) < ttl
+}
+
+// Stale reports whether e is older than ttl but still within maxAge,
+// i.e. usable under stale-while-revalidate.
+func (e Entry) Stale(ttl, maxAge time.Duration, now time.Time) bool {
+	age := now.Sub(e.FetchedAt)
+	return age >= ttl && age < maxAge
+}
+
+// TooOld reports whether e is older than maxAge and must not be
+// served even under stale-while-revalidate.
+func (e Entry) TooOld(maxAge time.Duration, now time.Time) bool {
+	return now.Sub(e.FetchedAt) >= maxAge
+}
+
+// Store is implemented by each cache backend (JSON file, BoltDB,
+// Redis, ...).
+type Store interface {
+	Get(ctx context.Context, city string) (Entry, bool, error)
+	Set(ctx context.Context, city string, entry Entry) error
+	Delete(ctx context.Context, city string) error
+	Close() error
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// JSONStore persists entries as a single JSON file, one entry per
+// city. It's the simplest Store and the default for single-shot CLI
+// use.
+type JSONStore struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// NewJSONStore loads (or initializes) a JSON file store at path.
+func NewJSONStore(path string) (*JSONStore, error) {
+	s := &JSONStore{path: path, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *JSONStore) Get(ctx context.Context, city string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[city]
+	return entry, ok, nil
+}
+
+func (s *JSONStore) Set(ctx context.Context, city string, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[city] = entry
+	return s.flushLocked()
+}
+
+func (s *JSONStore) Delete(ctx context.Context, city string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, city)
+	return s.flushLocked()
+}
+
+func (s *JSONStore) flushLocked() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+func (s *JSONStore) Close() error { return nil }
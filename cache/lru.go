@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// LRU wraps a Store and evicts the least-recently-used city once more
+// than Capacity distinct cities have been cached, so a long-running
+// daemon polling an unbounded set of cities doesn't grow its cache
+// forever.
+type LRU struct {
+	Store    Store
+	Capacity int
+
+	mu       sync.Mutex
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+// NewLRU wraps store with an LRU eviction policy capped at capacity
+// distinct cities.
+func NewLRU(store Store, capacity int) *LRU {
+	return &LRU{
+		Store:    store,
+		Capacity: capacity,
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+func (l *LRU) Get(ctx context.Context, city string) (Entry, bool, error) {
+	entry, ok, err := l.Store.Get(ctx, city)
+	if ok {
+		l.touch(city)
+	}
+	return entry, ok, err
+}
+
+func (l *LRU) Set(ctx context.Context, city string, entry Entry) error {
+	if err := l.Store.Set(ctx, city, entry); err != nil {
+		return err
+	}
+	l.touch(city)
+	return l.evictIfNeeded(ctx)
+}
+
+func (l *LRU) Delete(ctx context.Context, city string) error {
+	l.mu.Lock()
+	if el, ok := l.elements[city]; ok {
+		l.order.Remove(el)
+		delete(l.elements, city)
+	}
+	l.mu.Unlock()
+
+	return l.Store.Delete(ctx, city)
+}
+
+func (l *LRU) Close() error {
+	return l.Store.Close()
+}
+
+func (l *LRU) touch(city string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.elements[city]; ok {
+		l.order.MoveToFront(el)
+		return
+	}
+	l.elements[city] = l.order.PushFront(city)
+}
+
+func (l *LRU) evictIfNeeded(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.Capacity <= 0 || l.order.Len() <= l.Capacity {
+			l.mu.Unlock()
+			return nil
+		}
+		oldest := l.order.Back()
+		city := oldest.Value.(string)
+		l.order.Remove(oldest)
+		delete(l.elements, city)
+		l.mu.Unlock()
+
+		if err := l.Store.Delete(ctx, city); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore persists entries as JSON blobs in Redis, keyed by
+// "weather-client:cache:<city>". Good fit for daemons sharing a cache
+// across multiple instances.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore against an already-configured
+// *redis.Client.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client, prefix: "weather-client:cache:"}
+}
+
+func (s *RedisStore) Get(ctx context.Context, city string) (Entry, bool, error) {
+	data, err := s.client.Get(ctx, s.prefix+city).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, false, err
+	}
+	return entry, true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, city string, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+city, data, 0).Err()
+}
+
+func (s *RedisStore) Delete(ctx context.Context, city string) error {
+	return s.client.Del(ctx, s.prefix+city).Err()
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RefreshFunc fetches a fresh Entry for city, to be called by SWR
+// whenever the cached value is missing, stale, or too old.
+type RefreshFunc func(ctx context.Context, city string) (Entry, error)
+
+// SWR adds stale-while-revalidate semantics on top of a Store: an
+// entry younger than TTL is returned as-is, one between TTL and
+// MaxAge is returned immediately while a refresh runs in the
+// background, and anything older (or missing) is refreshed
+// synchronously. NegativeTTL controls how long a failed lookup is
+// remembered, so a city that just 404'd isn't retried on every call.
+type SWR struct {
+	Store       Store
+	TTL         time.Duration
+	MaxAge      time.Duration
+	NegativeTTL time.Duration
+}
+
+// Get returns the best available entry for city, calling refresh as
+// needed per the rules above.
+func (s *SWR) Get(ctx context.Context, city string, refresh RefreshFunc) (Entry, error) {
+	now := time.Now()
+
+	entry, ok, err := s.Store.Get(ctx, city)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if ok && entry.Negative && entry.Fresh(s.NegativeTTL, now) {
+		return Entry{}, fmt.Errorf("cache: %s: %w", city, errNegative)
+	}
+	if ok && entry.Negative {
+		// The remembered failure has expired: look the city up again.
+		ok = false
+	}
+
+	if ok && entry.Fresh(s.TTL, now) {
+		return entry, nil
+	}
+
+	if ok && entry.Stale(s.TTL, s.MaxAge, now) {
+		go s.revalidate(city, refresh)
+		return entry, nil
+	}
+
+	fresh, err := refresh(ctx, city)
+	if err != nil {
+		_ = s.Store.Set(ctx, city, Entry{FetchedAt: now, Negative: true})
+		return Entry{}, err
+	}
+
+	fresh.FetchedAt = now
+	if err := s.Store.Set(ctx, city, fresh); err != nil {
+		return fresh, err
+	}
+	return fresh, nil
+}
+
+func (s *SWR) revalidate(city string, refresh RefreshFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fresh, err := refresh(ctx, city)
+	if err != nil {
+		return
+	}
+	fresh.FetchedAt = time.Now()
+	_ = s.Store.Set(ctx, city, fresh)
+}
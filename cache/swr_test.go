@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// memStore is a minimal in-memory Store for exercising SWR without
+// touching disk.
+type memStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func newMemStore() *memStore {
+	return &memStore{entries: make(map[string]Entry)}
+}
+
+func (s *memStore) Get(ctx context.Context, city string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[city]
+	return e, ok, nil
+}
+
+func (s *memStore) Set(ctx context.Context, city string, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[city] = entry
+	return nil
+}
+
+func (s *memStore) Delete(ctx context.Context, city string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, city)
+	return nil
+}
+
+func (s *memStore) Close() error { return nil }
+
+func entryPayload(city string) map[string]providers.Observation {
+	return map[string]providers.Observation{"owm": {City: city}}
+}
+
+func TestSWRGetFreshEntrySkipsRefresh(t *testing.T) {
+	store := newMemStore()
+	_ = store.Set(context.Background(), "Paris", Entry{FetchedAt: time.Now(), Payloads: entryPayload("Paris")})
+
+	swr := &SWR{Store: store, TTL: time.Hour, MaxAge: 2 * time.Hour, NegativeTTL: time.Minute}
+
+	called := false
+	entry, err := swr.Get(context.Background(), "Paris", func(ctx context.Context, city string) (Entry, error) {
+		called = true
+		return Entry{}, nil
+	})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if called {
+		t.Fatal("refresh should not be called for a fresh entry")
+	}
+	if entry.Payloads["owm"].City != "Paris" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestSWRGetStaleEntryServedImmediatelyAndRevalidates(t *testing.T) {
+	store := newMemStore()
+	staleAt := time.Now().Add(-90 * time.Minute)
+	_ = store.Set(context.Background(), "Berlin", Entry{FetchedAt: staleAt, Payloads: entryPayload("Berlin")})
+
+	swr := &SWR{Store: store, TTL: time.Hour, MaxAge: 2 * time.Hour, NegativeTTL: time.Minute}
+
+	refreshed := make(chan struct{})
+	entry, err := swr.Get(context.Background(), "Berlin", func(ctx context.Context, city string) (Entry, error) {
+		close(refreshed)
+		return Entry{Payloads: entryPayload("Berlin (fresh)")}, nil
+	})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if entry.Payloads["owm"].City != "Berlin" {
+		t.Fatalf("expected the stale entry to be served immediately, got %+v", entry)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("expected a background revalidate call")
+	}
+}
+
+func TestSWRGetTooOldEntryRefreshesSynchronously(t *testing.T) {
+	store := newMemStore()
+	tooOld := time.Now().Add(-3 * time.Hour)
+	_ = store.Set(context.Background(), "Oslo", Entry{FetchedAt: tooOld, Payloads: entryPayload("Oslo")})
+
+	swr := &SWR{Store: store, TTL: time.Hour, MaxAge: 2 * time.Hour, NegativeTTL: time.Minute}
+
+	entry, err := swr.Get(context.Background(), "Oslo", func(ctx context.Context, city string) (Entry, error) {
+		return Entry{Payloads: entryPayload("Oslo (fresh)")}, nil
+	})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if entry.Payloads["owm"].City != "Oslo (fresh)" {
+		t.Fatalf("expected a synchronous refresh, got %+v", entry)
+	}
+}
+
+func TestSWRGetMissingEntryRefreshesSynchronously(t *testing.T) {
+	store := newMemStore()
+	swr := &SWR{Store: store, TTL: time.Hour, MaxAge: 2 * time.Hour, NegativeTTL: time.Minute}
+
+	called := false
+	entry, err := swr.Get(context.Background(), "Rome", func(ctx context.Context, city string) (Entry, error) {
+		called = true
+		return Entry{Payloads: entryPayload("Rome")}, nil
+	})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !called {
+		t.Fatal("expected refresh to be called for a missing entry")
+	}
+	if entry.Payloads["owm"].City != "Rome" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestSWRGetFailedRefreshCachesNegativeResult(t *testing.T) {
+	store := newMemStore()
+	swr := &SWR{Store: store, TTL: time.Hour, MaxAge: 2 * time.Hour, NegativeTTL: time.Minute}
+
+	wantErr := errors.New("city not found")
+	_, err := swr.Get(context.Background(), "Nowhere", func(ctx context.Context, city string) (Entry, error) {
+		return Entry{}, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the refresh error, got %v", err)
+	}
+
+	calls := 0
+	_, err = swr.Get(context.Background(), "Nowhere", func(ctx context.Context, city string) (Entry, error) {
+		calls++
+		return Entry{Payloads: entryPayload("Nowhere")}, nil
+	})
+	if calls != 0 {
+		t.Fatal("refresh should not be called again within NegativeTTL")
+	}
+	if !errors.Is(err, errNegative) {
+		t.Fatalf("expected a cached negative result, got %v", err)
+	}
+}
+
+func TestSWRGetNegativeResultExpiresAfterNegativeTTL(t *testing.T) {
+	store := newMemStore()
+	_ = store.Set(context.Background(), "Nowhere", Entry{FetchedAt: time.Now().Add(-2 * time.Minute), Negative: true})
+
+	// NegativeTTL has elapsed, so the negative entry is no longer served
+	// and the city is retried synchronously.
+	swr := &SWR{Store: store, TTL: 30 * time.Second, MaxAge: 90 * time.Second, NegativeTTL: time.Minute}
+
+	called := false
+	_, err := swr.Get(context.Background(), "Nowhere", func(ctx context.Context, city string) (Entry, error) {
+		called = true
+		return Entry{Payloads: entryPayload("Nowhere")}, nil
+	})
+	if !called {
+		t.Fatal("expected refresh to retry once the negative TTL has elapsed")
+	}
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+}
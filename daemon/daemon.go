@@ -0,0 +1,211 @@
+// Package daemon implements the long-running polling mode: it reads a
+// list of cities and a poll interval from a YAML config and
+// continuously pushes observations to one or more sinks.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Alyanaky/go_weather-client/cache"
+	"github.com/Alyanaky/go_weather-client/providers"
+	"github.com/Alyanaky/go_weather-client/sink"
+)
+
+// Config is the daemon's YAML configuration.
+type Config struct {
+	Cities       []string `yaml:"cities"`
+	Providers    []string `yaml:"providers"`
+	PollInterval string   `yaml:"poll_interval"`
+
+	Cache CacheConfig `yaml:"cache"`
+
+	Sinks struct {
+		InfluxDB   *sink.InfluxConfig     `yaml:"influxdb"`
+		Prometheus *sink.PrometheusConfig `yaml:"prometheus"`
+	} `yaml:"sinks"`
+}
+
+// CacheConfig selects and tunes the Store the daemon polls through, so
+// a poll that lands within TTL of the last one reuses the cached
+// observation instead of hitting providers again, and a city that's
+// failing lookups isn't retried on every tick until NegativeTTL lapses.
+type CacheConfig struct {
+	// Backend is "json" (default), "bolt", or "redis".
+	Backend string `yaml:"backend"`
+	// Path is the file path for the json and bolt backends.
+	Path string `yaml:"path"`
+	// RedisAddr is the "host:port" to dial for the redis backend.
+	RedisAddr string `yaml:"redis_addr"`
+
+	TTL         string `yaml:"ttl"`
+	MaxAge      string `yaml:"max_age"`
+	NegativeTTL string `yaml:"negative_ttl"`
+
+	// MaxEntries bounds the number of distinct cities kept in the
+	// cache via LRU eviction. 0 (the default) leaves it unbounded,
+	// which is fine for a small, static Cities list but should be set
+	// for daemons that see many distinct cities over their lifetime.
+	MaxEntries int `yaml:"max_entries"`
+}
+
+// LoadConfig reads and parses a daemon YAML config file.
+func LoadConfig(data []byte) (Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	if len(cfg.Providers) == 0 {
+		cfg.Providers = []string{"owm"}
+	}
+	if cfg.PollInterval == "" {
+		cfg.PollInterval = "5m"
+	}
+	if cfg.Cache.Backend == "" {
+		cfg.Cache.Backend = "json"
+	}
+	if cfg.Cache.Path == "" {
+		cfg.Cache.Path = "daemon_cache.json"
+	}
+	if cfg.Cache.TTL == "" {
+		cfg.Cache.TTL = cfg.PollInterval
+	}
+	if cfg.Cache.MaxAge == "" {
+		cfg.Cache.MaxAge = "30m"
+	}
+	if cfg.Cache.NegativeTTL == "" {
+		cfg.Cache.NegativeTTL = "5m"
+	}
+	return cfg, nil
+}
+
+// buildStore constructs the Store named by cfg, wrapping it with LRU
+// eviction when cfg.MaxEntries is set.
+func buildStore(cfg CacheConfig) (cache.Store, error) {
+	var store cache.Store
+	var err error
+
+	switch cfg.Backend {
+	case "json":
+		store, err = cache.NewJSONStore(cfg.Path)
+	case "bolt":
+		store, err = cache.NewBoltStore(cfg.Path)
+	case "redis":
+		store = cache.NewRedisStore(redis.NewClient(&redis.Options{Addr: cfg.RedisAddr}))
+	default:
+		return nil, fmt.Errorf("daemon: unknown cache backend %q", cfg.Backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("daemon: opening %s cache: %w", cfg.Backend, err)
+	}
+
+	if cfg.MaxEntries > 0 {
+		store = cache.NewLRU(store, cfg.MaxEntries)
+	}
+	return store, nil
+}
+
+// Run polls every city on cfg's interval until ctx is cancelled,
+// pushing each observation to every configured sink. It shares a
+// single *http.Client, with connection pooling, across the whole run.
+func Run(ctx context.Context, cfg Config) error {
+	interval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil {
+		return fmt.Errorf("daemon: invalid poll_interval %q: %w", cfg.PollInterval, err)
+	}
+	ttl, err := time.ParseDuration(cfg.Cache.TTL)
+	if err != nil {
+		return fmt.Errorf("daemon: invalid cache.ttl %q: %w", cfg.Cache.TTL, err)
+	}
+	maxAge, err := time.ParseDuration(cfg.Cache.MaxAge)
+	if err != nil {
+		return fmt.Errorf("daemon: invalid cache.max_age %q: %w", cfg.Cache.MaxAge, err)
+	}
+	negativeTTL, err := time.ParseDuration(cfg.Cache.NegativeTTL)
+	if err != nil {
+		return fmt.Errorf("daemon: invalid cache.negative_ttl %q: %w", cfg.Cache.NegativeTTL, err)
+	}
+
+	store, err := buildStore(cfg.Cache)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	swr := &cache.SWR{Store: store, TTL: ttl, MaxAge: maxAge, NegativeTTL: negativeTTL}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	var sinks []sink.Sink
+	if cfg.Sinks.InfluxDB != nil {
+		sinks = append(sinks, sink.NewInflux(*cfg.Sinks.InfluxDB, httpClient))
+	}
+	if cfg.Sinks.Prometheus != nil {
+		sinks = append(sinks, sink.NewPrometheus(*cfg.Sinks.Prometheus))
+	}
+	defer func() {
+		for _, s := range sinks {
+			s.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	poll(ctx, cfg, sinks, swr)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			poll(ctx, cfg, sinks, swr)
+		}
+	}
+}
+
+// poll fetches each city once per tick, through swr so a city whose
+// entry is still fresh is served from cache instead of hitting every
+// configured provider again, and one that just failed isn't retried
+// until its negative entry expires.
+func poll(ctx context.Context, cfg Config, sinks []sink.Sink, swr *cache.SWR) {
+	for _, city := range cfg.Cities {
+		entry, err := swr.Get(ctx, city, func(ctx context.Context, city string) (cache.Entry, error) {
+			payloads := make(map[string]providers.Observation, len(cfg.Providers))
+			for _, name := range cfg.Providers {
+				p, err := providers.Get(name)
+				if err != nil {
+					fmt.Println("daemon:", err)
+					continue
+				}
+
+				obs, err := p.Current(ctx, city)
+				if err != nil {
+					fmt.Printf("daemon: %s/%s: %v\n", city, name, err)
+					continue
+				}
+				payloads[name] = obs
+			}
+			if len(payloads) == 0 {
+				return cache.Entry{}, fmt.Errorf("daemon: no provider returned data for %s", city)
+			}
+			return cache.Entry{Payloads: payloads}, nil
+		})
+		if err != nil {
+			fmt.Printf("daemon: %s: %v\n", city, err)
+			continue
+		}
+
+		for _, obs := range entry.Payloads {
+			for _, s := range sinks {
+				if err := s.Write(ctx, obs); err != nil {
+					fmt.Printf("daemon: sink write failed: %v\n", err)
+				}
+			}
+		}
+	}
+}
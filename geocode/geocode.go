@@ -0,0 +1,34 @@
+// Package geocode resolves city names to coordinates so providers can
+// be queried by (lat, lon) instead of a free-form city string.
+package geocode
+
+import (
+	"context"
+	"strings"
+)
+
+// Location is a resolved place: its coordinates plus enough metadata
+// to disambiguate it from same-named places elsewhere.
+type Location struct {
+	Name    string  `json:"name"`
+	Country string  `json:"country"`
+	Admin   string  `json:"admin"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+// Geocoder resolves a free-form query (e.g. "Paris" or "Paris,FR")
+// into a Location.
+type Geocoder interface {
+	Resolve(ctx context.Context, query string) (Location, error)
+}
+
+// ParseCityArg splits a "--city" value of the form "City,CC" into a
+// bare city name and an optional ISO country code.
+func ParseCityArg(s string) (city, country string) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) == 2 {
+		return strings.TrimSpace(parts[0]), strings.ToUpper(strings.TrimSpace(parts[1]))
+	}
+	return strings.TrimSpace(s), ""
+}
@@ -0,0 +1,60 @@
+package geocode
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Alyanaky/go_weather-client/httpx"
+)
+
+// OpenMeteoGeocoder resolves cities via Open-Meteo's free geocoding
+// API. It requires no API key, so it's used as a fallback when no
+// OpenWeatherMap key is configured. For a "City,CC" query it returns
+// the first of the top ten name matches whose country code is CC.
+type OpenMeteoGeocoder struct {
+	client *httpx.Client
+}
+
+// NewOpenMeteoGeocoder builds an OpenMeteoGeocoder, routing requests
+// through httpx like every other provider.
+func NewOpenMeteoGeocoder() *OpenMeteoGeocoder {
+	return &OpenMeteoGeocoder{client: httpx.NewClient(httpx.DefaultConfig())}
+}
+
+type openMeteoGeocodeResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Country   string  `json:"country_code"`
+		Admin1    string  `json:"admin1"`
+	} `json:"results"`
+}
+
+func (g *OpenMeteoGeocoder) Resolve(ctx context.Context, query string) (Location, error) {
+	city, country := ParseCityArg(query)
+
+	endpoint := fmt.Sprintf("https://geocoding-api.open-meteo.com/v1/search?name=%s&count=10",
+		url.QueryEscape(city))
+
+	var resp openMeteoGeocodeResponse
+	if err := g.client.GetJSON(ctx, endpoint, &resp); err != nil {
+		return Location{}, err
+	}
+
+	for _, r := range resp.Results {
+		if country != "" && !strings.EqualFold(r.Country, country) {
+			continue
+		}
+		return Location{
+			Name:    r.Name,
+			Country: r.Country,
+			Admin:   r.Admin1,
+			Lat:     r.Latitude,
+			Lon:     r.Longitude,
+		}, nil
+	}
+	return Location{}, fmt.Errorf("geocode: no match for %q", query)
+}
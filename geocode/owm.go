@@ -0,0 +1,58 @@
+package geocode
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Alyanaky/go_weather-client/httpx"
+)
+
+// OWMGeocoder resolves cities via OpenWeatherMap's geocoding API.
+type OWMGeocoder struct {
+	APIKey string
+	client *httpx.Client
+}
+
+// NewOWMGeocoder builds an OWMGeocoder, routing requests through httpx
+// like every other provider so a hung geocoder call can't hang the CLI.
+func NewOWMGeocoder(apiKey string) *OWMGeocoder {
+	return &OWMGeocoder{APIKey: apiKey, client: httpx.NewClient(httpx.DefaultConfig())}
+}
+
+type owmGeocodeResult struct {
+	Name    string  `json:"name"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	Country string  `json:"country"`
+	State   string  `json:"state"`
+}
+
+func (g *OWMGeocoder) Resolve(ctx context.Context, query string) (Location, error) {
+	city, country := ParseCityArg(query)
+
+	q := city
+	if country != "" {
+		q = city + "," + country
+	}
+
+	endpoint := fmt.Sprintf("https://api.openweathermap.org/geo/1.0/direct?q=%s&limit=1&appid=%s",
+		url.QueryEscape(q), url.QueryEscape(g.APIKey))
+
+	var results []owmGeocodeResult
+	if err := g.client.GetJSON(ctx, endpoint, &results); err != nil {
+		return Location{}, err
+	}
+	if len(results) == 0 {
+		return Location{}, fmt.Errorf("geocode: no match for %q", query)
+	}
+
+	r := results[0]
+	return Location{
+		Name:    r.Name,
+		Country: r.Country,
+		Admin:   r.State,
+		Lat:     r.Lat,
+		Lon:     r.Lon,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// Resolver tries OpenWeatherMap's geocoder first (if an API key is
+// configured) and falls back to Open-Meteo's free geocoder, caching
+// every result to avoid re-resolving the same query.
+type Resolver struct {
+	primary  Geocoder
+	fallback Geocoder
+
+	cachePath string
+	mu        sync.Mutex
+	cache     map[string]Location
+}
+
+// NewResolver builds a Resolver. If owmAPIKey is empty, OWM is
+// skipped and Open-Meteo is used directly.
+func NewResolver(owmAPIKey, cachePath string) *Resolver {
+	r := &Resolver{
+		fallback:  NewOpenMeteoGeocoder(),
+		cachePath: cachePath,
+		cache:     make(map[string]Location),
+	}
+	if owmAPIKey != "" {
+		r.primary = NewOWMGeocoder(owmAPIKey)
+	}
+
+	if data, err := os.ReadFile(cachePath); err == nil {
+		_ = json.Unmarshal(data, &r.cache)
+	}
+
+	return r
+}
+
+// Resolve resolves query, consulting the cache before calling out to
+// a geocoder.
+func (r *Resolver) Resolve(ctx context.Context, query string) (Location, error) {
+	r.mu.Lock()
+	if loc, ok := r.cache[query]; ok {
+		r.mu.Unlock()
+		return loc, nil
+	}
+	r.mu.Unlock()
+
+	var loc Location
+	var err error
+	if r.primary != nil {
+		loc, err = r.primary.Resolve(ctx, query)
+	}
+	if r.primary == nil || err != nil {
+		loc, err = r.fallback.Resolve(ctx, query)
+	}
+	if err != nil {
+		return Location{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache[query] = loc
+	if r.cachePath != "" {
+		if data, err := json.MarshalIndent(r.cache, "", "  "); err == nil {
+			_ = os.WriteFile(r.cachePath, data, 0644)
+		}
+	}
+
+	return loc, nil
+}
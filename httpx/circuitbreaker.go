@@ -0,0 +1,60 @@
+package httpx
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker trips after Threshold consecutive failures and
+// refuses calls for Cooldown before allowing a single trial request
+// through again (half-open).
+type circuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// allow reports whether a request may proceed right now.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if time.Since(b.openedAt) >= b.Cooldown {
+		// Half-open: let one request through to probe recovery. The
+		// breaker stays open for other callers until the probe's
+		// outcome is recorded, and a failed probe reopens it.
+		b.openedAt = time.Now()
+		b.failures = b.Threshold - 1
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.open = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.Threshold {
+		b.open = true
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,73 @@
+package httpx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerAllowsUntilThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("allow() should be true before the threshold is reached (failure %d)", i)
+		}
+		b.recordFailure()
+	}
+	if !b.allow() {
+		t.Fatal("allow() should still be true with failures one short of the threshold")
+	}
+}
+
+func TestCircuitBreakerOpensAtThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		b.recordFailure()
+	}
+	if b.allow() {
+		t.Fatal("allow() should be false once the breaker has tripped")
+	}
+}
+
+func TestCircuitBreakerHalfOpensAfterCooldown(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("allow() should be false immediately after tripping")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("allow() should let a probe request through once the cooldown elapses")
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(1, 10*time.Millisecond)
+
+	b.recordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("allow() should let the probe request through")
+	}
+	b.recordFailure()
+
+	if b.allow() {
+		t.Fatal("a failed probe should reopen the breaker immediately")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessCloses(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute)
+
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+	if !b.allow() {
+		t.Fatal("a single failure after a reset should not trip the breaker")
+	}
+}
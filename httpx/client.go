@@ -0,0 +1,205 @@
+// Package httpx wraps *http.Client with the cross-cutting concerns
+// every provider needs: timeouts, retries with backoff, per-provider
+// rate limiting, circuit breaking, and typed errors for the common
+// failure modes (unauthorized, rate limited, city not found).
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const userAgent = "go_weather-client/1.0 (+https://github.com/Alyanaky/go_weather-client)"
+
+// Config tunes a Client's retry, rate-limiting and circuit-breaking
+// behavior.
+type Config struct {
+	// Timeout bounds a single HTTP round trip; each retry attempt gets
+	// its own Timeout.
+	Timeout time.Duration
+
+	// RequestsPerMinute and Burst size the provider's token bucket.
+	// A free-tier provider like OWM caps out around 60/min.
+	RequestsPerMinute float64
+	Burst             int
+
+	MaxRetries int
+
+	// CircuitThreshold is the number of consecutive failures that
+	// trips the breaker; CircuitCooldown is how long it stays open.
+	CircuitThreshold int
+	CircuitCooldown  time.Duration
+}
+
+// DefaultConfig returns sane defaults for a free-tier weather API.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:           10 * time.Second,
+		RequestsPerMinute: 60,
+		Burst:             5,
+		MaxRetries:        3,
+		CircuitThreshold:  5,
+		CircuitCooldown:   30 * time.Second,
+	}
+}
+
+// Client is an HTTP client wrapper providers route every request
+// through.
+type Client struct {
+	http    *http.Client
+	limiter *rate.Limiter
+	breaker *circuitBreaker
+	cfg     Config
+}
+
+// NewClient builds a Client from cfg.
+func NewClient(cfg Config) *Client {
+	return &Client{
+		http:    &http.Client{Timeout: cfg.Timeout},
+		limiter: rate.NewLimiter(rate.Limit(cfg.RequestsPerMinute/60.0), cfg.Burst),
+		breaker: newCircuitBreaker(cfg.CircuitThreshold, cfg.CircuitCooldown),
+		cfg:     cfg,
+	}
+}
+
+// GetJSON issues a GET to url, retrying on 5xx/429 with exponential
+// backoff (honoring Retry-After when present), and decodes the
+// response body into v on success.
+func (c *Client) GetJSON(ctx context.Context, url string, v interface{}) error {
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(body, v)
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		body, retryAfter, err := c.do(ctx, url)
+		if err == nil {
+			c.breaker.recordSuccess()
+			return body, nil
+		}
+
+		lastErr = err
+		if !isRetryable(err) {
+			c.breaker.recordFailure()
+			return nil, err
+		}
+
+		if retryAfter > 0 {
+			if err := sleep(ctx, retryAfter); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c.breaker.recordFailure()
+	return nil, lastErr
+}
+
+func (c *Client) do(ctx context.Context, url string) (body []byte, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, 0, nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, 0, ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, 0, ErrCityNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")), ErrRateLimited
+	case resp.StatusCode >= 500:
+		return nil, 0, &serverError{status: resp.StatusCode, url: url}
+	default:
+		return nil, 0, fmt.Errorf("httpx: unexpected status %d from %s: %s", resp.StatusCode, url, body)
+	}
+}
+
+// serverError marks a 5xx response as retryable.
+type serverError struct {
+	status int
+	url    string
+}
+
+func (e *serverError) Error() string {
+	return fmt.Sprintf("httpx: server error %d from %s", e.status, e.url)
+}
+
+func isRetryable(err error) bool {
+	if err == ErrRateLimited {
+		return true
+	}
+	_, ok := err.(*serverError)
+	return ok
+}
+
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt-1)) * 500 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base + jitter
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decodeJSON(body []byte, v interface{}) error {
+	return json.Unmarshal(body, v)
+}
+
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastConfig() Config {
+	return Config{
+		Timeout:           time.Second,
+		RequestsPerMinute: 6000,
+		Burst:             10,
+		MaxRetries:        3,
+		CircuitThreshold:  2,
+		CircuitCooldown:   50 * time.Millisecond,
+	}
+}
+
+func TestClientGetJSONRetriesOnServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"ok": "yes"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(fastConfig())
+	var out map[string]string
+	if err := c.GetJSON(context.Background(), srv.URL, &out); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if out["ok"] != "yes" {
+		t.Fatalf("unexpected body: %+v", out)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClientGetJSONDoesNotRetryUnauthorized(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewClient(fastConfig())
+	err := c.GetJSON(context.Background(), srv.URL, &struct{}{})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected exactly 1 attempt for a non-retryable error, got %d", got)
+	}
+}
+
+func TestClientGetJSONGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := fastConfig()
+	cfg.MaxRetries = 2
+	c := NewClient(cfg)
+
+	err := c.GetJSON(context.Background(), srv.URL, &struct{}{})
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Fatalf("expected 1 initial attempt + 2 retries = 3 calls, got %d", got)
+	}
+}
+
+func TestClientCircuitOpensAfterRepeatedFailures(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	cfg := fastConfig()
+	cfg.CircuitThreshold = 2
+	c := NewClient(cfg)
+
+	for i := 0; i < 2; i++ {
+		if err := c.GetJSON(context.Background(), srv.URL, &struct{}{}); !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("attempt %d: expected ErrUnauthorized, got %v", i, err)
+		}
+	}
+
+	callsBefore := atomic.LoadInt32(&calls)
+	err := c.GetJSON(context.Background(), srv.URL, &struct{}{})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen once the breaker trips, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != callsBefore {
+		t.Fatal("an open circuit should not reach the server at all")
+	}
+
+	time.Sleep(cfg.CircuitCooldown + 20*time.Millisecond)
+
+	if err := c.GetJSON(context.Background(), srv.URL, &struct{}{}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected the breaker's probe request to reach the server again, got %v", err)
+	}
+}
+
+func TestClientGetJSONHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	start := time.Now()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"ok": "yes"})
+	}))
+	defer srv.Close()
+
+	c := NewClient(fastConfig())
+	var out map[string]string
+	if err := c.GetJSON(context.Background(), srv.URL, &out); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected GetJSON to honor Retry-After and wait at least 1s, took %s", elapsed)
+	}
+}
@@ -0,0 +1,12 @@
+package httpx
+
+import "errors"
+
+// Typed errors providers can switch on to print an actionable message
+// instead of a generic "failed to retrieve weather data".
+var (
+	ErrUnauthorized = errors.New("httpx: unauthorized (check your API key)")
+	ErrRateLimited  = errors.New("httpx: rate limited by provider")
+	ErrCityNotFound = errors.New("httpx: city not found")
+	ErrCircuitOpen  = errors.New("httpx: circuit breaker open, not sending request")
+)
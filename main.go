@@ -1,192 +1,475 @@
 package main
 
 import (
-    "encoding/json"
-    "flag"
-    "fmt"
-    "io/ioutil"
-    "net/http"
-    "os"
-    "sync"
-    "time"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Alyanaky/go_weather-client/aggregate"
+	"github.com/Alyanaky/go_weather-client/cache"
+	"github.com/Alyanaky/go_weather-client/daemon"
+	"github.com/Alyanaky/go_weather-client/geocode"
+	"github.com/Alyanaky/go_weather-client/httpx"
+	"github.com/Alyanaky/go_weather-client/providers"
+	"github.com/Alyanaky/go_weather-client/render"
 )
 
-type WeatherData struct {
-    Main struct {
-        Temp     float64 `json:"temp"`
-        Humidity int     `json:"humidity"`
-    } `json:"main"`
-    Weather []struct {
-        Description string `json:"description"`
-    } `json:"weather"`
-    Name string `json:"name"`
-}
+const (
+	cacheTTL         = 10 * time.Minute
+	cacheMaxAge      = 30 * time.Minute
+	cacheNegativeTTL = 5 * time.Minute
+)
 
 type Config struct {
-    OpenWeatherMapAPIKey string `json:"openweathermap_api_key"`
-    WeatherAPIKey         string `json:"weatherapi_api_key"`
+	OpenWeatherMapAPIKey string `json:"openweathermap_api_key"`
+	WeatherAPIKey        string `json:"weatherapi_api_key"`
 }
 
-type Cache struct {
-    Data map[string]WeatherData `json:"data"`
-    Timestamp time.Time `json:"timestamp"`
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "daemon" {
+		runDaemon(os.Args[2:])
+		return
+	}
+
+	city := flag.String("city", "", `City name, optionally "City,CC" to disambiguate`)
+	citiesList := flag.String("cities", "", "Comma-separated list of cities to query concurrently")
+	citiesFile := flag.String("cities-file", "", "Path to a file with one city per line")
+	lat := flag.String("lat", "", "Latitude for a direct coordinate lookup (requires --lon)")
+	lon := flag.String("lon", "", "Longitude for a direct coordinate lookup (requires --lat)")
+	workers := flag.Int("workers", 5, "Max concurrent city lookups for --cities/--cities-file")
+	providerList := flag.String("providers", "owm,weatherapi", "Comma-separated list of providers to query (owm, weatherapi, open-meteo, meteofrance)")
+	mode := flag.String("mode", "current", "What to fetch: current, forecast, alerts")
+	agg := flag.String("agg", "average", "How to combine multiple providers' current observations: average, min, max, median")
+	days := flag.Int("days", 3, "Number of days for --mode=forecast")
+	output := flag.String("output", "text", "Output format: text, json, yaml, table")
+	noColor := flag.Bool("no-color", false, "Disable ANSI color in text output")
+	flag.Parse()
+
+	config, err := loadConfig("config.json")
+	if err != nil {
+		fmt.Println("Error loading config:", err)
+		os.Exit(1)
+	}
+
+	providers.NewOpenWeatherMap(config.OpenWeatherMapAPIKey)
+	providers.NewWeatherAPI(config.WeatherAPIKey)
+	providers.NewOpenMeteo()
+	providers.NewMeteoFrance()
+
+	names := splitNonEmpty(*providerList)
+	if len(names) == 0 {
+		fmt.Println("No providers specified")
+		os.Exit(1)
+	}
+
+	formatterName := *output
+	cities, err := collectCities(*city, *citiesList, *citiesFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	resolver := geocode.NewResolver(config.OpenWeatherMapAPIKey, "geocode_cache.json")
+
+	if len(cities) > 1 {
+		if *output == "text" {
+			formatterName = "table"
+		}
+		formatter, err := render.New(formatterName, render.ColorEnabled(*noColor))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		out, err := formatter.Format(runBatch(context.Background(), names, cities, *workers, resolver, aggregate.ByName(*agg)))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(out)
+		return
+	}
+	if len(cities) == 1 {
+		*city = cities[0]
+	}
+
+	formatter, err := render.New(formatterName, render.ColorEnabled(*noColor))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if *lat != "" || *lon != "" {
+		latF, lonF, err := parseCoordinates(*lat, *lon)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		obs := fetchCoordinates(context.Background(), names, latF, lonF, aggregate.ByName(*agg))
+		out, err := formatter.Format([]render.Result{{Query: fmt.Sprintf("%s,%s", *lat, *lon), Observation: obs}})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(out)
+		return
+	}
+
+	if *city == "" {
+		fmt.Println("City name must be specified")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	loc, err := resolver.Resolve(ctx, *city)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	store, err := cache.NewJSONStore("cache.json")
+	if err != nil {
+		fmt.Println("Error loading cache:", err)
+		store, _ = cache.NewJSONStore(os.DevNull)
+	}
+	swr := &cache.SWR{Store: store, TTL: cacheTTL, MaxAge: cacheMaxAge, NegativeTTL: cacheNegativeTTL}
+
+	switch *mode {
+	case "current", "alerts":
+		entry, err := swr.Get(ctx, *city, func(ctx context.Context, city string) (cache.Entry, error) {
+			obs := fetchCurrentByCoordinates(ctx, names, loc.Lat, loc.Lon)
+			if len(obs) == 0 {
+				return cache.Entry{}, fmt.Errorf("failed to retrieve weather data for %s", city)
+			}
+
+			payloads := make(map[string]providers.Observation, len(obs))
+			for _, o := range obs {
+				payloads[o.Provider] = o
+			}
+			return cache.Entry{Payloads: payloads}, nil
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		obs := make([]providers.Observation, 0, len(entry.Payloads))
+		for _, name := range names {
+			if o, ok := entry.Payloads[name]; ok {
+				obs = append(obs, o)
+			}
+		}
+
+		var result render.Result
+		if *mode == "alerts" {
+			result = render.Result{Query: *city, Location: loc, Alerts: collectAlerts(obs)}
+		} else {
+			result = render.Result{
+				Query:       *city,
+				Location:    loc,
+				Observation: aggregate.Observation(obs, aggregate.ByName(*agg)),
+				FromCache:   entry.Stale(cacheTTL, cacheMaxAge, time.Now()),
+			}
+		}
+		out, err := formatter.Format([]render.Result{result})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(out)
+
+	case "forecast":
+		out, err := formatter.Format(fetchForecast(ctx, names, loc.Name, *days))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(out)
+
+	default:
+		fmt.Printf("Unknown mode %q\n", *mode)
+		os.Exit(1)
+	}
 }
 
-func main() {
-    city := flag.String("city", "", "City name")
-    flag.Parse()
-
-    if *city == "" {
-        fmt.Println("City name must be specified")
-        os.Exit(1)
-    }
-
-    config, err := loadConfig("config.json")
-    if err != nil {
-        fmt.Println("Error loading config:", err)
-        os.Exit(1)
-    }
-
-    cache, err := loadCache("cache.json")
-    if err != nil {
-        fmt.Println("Error loading cache:", err)
-    }
-
-    if cache != nil && time.Since(cache.Timestamp).Minutes() < 10 {
-        if weatherData, exists := cache.Data[*city]; exists {
-            fmt.Printf("Weather in %s (from cache):\n", weatherData.Name)
-            fmt.Printf("Temperature: %.2f°C\n", weatherData.Main.Temp)
-            fmt.Printf("Humidity: %d%%\n", weatherData.Main.Humidity)
-            fmt.Printf("Description: %s\n", weatherData.Weather[0].Description)
-            return
-        }
-    }
-
-    var wg sync.WaitGroup
-    var weatherDataList []WeatherData
-    var mutex = &sync.Mutex{}
-
-    wg.Add(1)
-    go func() {
-        defer wg.Done()
-        data, err := getWeatherFromOpenWeatherMap(*city, config.OpenWeatherMapAPIKey)
-        if err == nil {
-            mutex.Lock()
-            weatherDataList = append(weatherDataList, data)
-            mutex.Unlock()
-        }
-    }()
-
-    wg.Add(1)
-    go func() {
-        defer wg.Done()
-        data, err := getWeatherFromWeatherAPI(*city, config.WeatherAPIKey)
-        if err == nil {
-            mutex.Lock()
-            weatherDataList = append(weatherDataList, data)
-            mutex.Unlock()
-        }
-    }()
-
-    wg.Wait()
-
-    if len(weatherDataList) == 0 {
-        fmt.Println("Failed to retrieve weather data")
-        os.Exit(1)
-    }
-
-
-    var totalTemp float64
-    for _, data := range weatherDataList {
-        totalTemp += data.Main.Temp
-    }
-    avgTemp := totalTemp / float64(len(weatherDataList))
-
-
-    fmt.Printf("Average Temperature in %s:\n", weatherDataList[0].Name)
-    fmt.Printf("Temperature: %.2f°C\n", avgTemp)
-
-    if cache == nil {
-        cache = &Cache{Data: make(map[string]WeatherData)}
-    }
-    cache.Data[*city] = weatherDataList[0]
-    cache.Timestamp = time.Now()
-    err = saveCache("cache.json", cache)
-    if err != nil {
-        fmt.Println("Error saving cache:", err)
-    }
+func runDaemon(args []string) {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	configPath := fs.String("config", "daemon.yaml", "Path to the daemon YAML config")
+	fs.Parse(args)
+
+	data, err := os.ReadFile(*configPath)
+	if err != nil {
+		fmt.Println("Error reading daemon config:", err)
+		os.Exit(1)
+	}
+
+	cfg, err := daemon.LoadConfig(data)
+	if err != nil {
+		fmt.Println("Error parsing daemon config:", err)
+		os.Exit(1)
+	}
+
+	config, err := loadConfig("config.json")
+	if err != nil {
+		fmt.Println("Error loading config:", err)
+		os.Exit(1)
+	}
+
+	providers.NewOpenWeatherMap(config.OpenWeatherMapAPIKey)
+	providers.NewWeatherAPI(config.WeatherAPIKey)
+	providers.NewOpenMeteo()
+	providers.NewMeteoFrance()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := daemon.Run(ctx, cfg); err != nil && err != context.Canceled {
+		fmt.Println("daemon exited:", err)
+		os.Exit(1)
+	}
 }
 
+// collectCities merges --city, --cities and --cities-file into a
+// single ordered, deduplicated list of city queries.
+func collectCities(city, citiesList, citiesFile string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+
+	add := func(q string) {
+		q = strings.TrimSpace(q)
+		if q == "" || seen[q] {
+			return
+		}
+		seen[q] = true
+		out = append(out, q)
+	}
+
+	if citiesFile != "" {
+		data, err := os.ReadFile(citiesFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading cities file: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			add(line)
+		}
+	}
+
+	for _, q := range splitNonEmpty(citiesList) {
+		add(q)
+	}
+
+	add(city)
+
+	return out, nil
+}
 
-func loadConfig(filename string) (Config, error) {
-    data, err := ioutil.ReadFile(filename)
-    if err != nil {
-        return Config{}, err
-    }
-
-    var config Config
-    err = json.Unmarshal(data, &config)
-    if err != nil {
-        return Config{}, err
-    }
-
-    return config, nil
+func parseCoordinates(latStr, lonStr string) (lat, lon float64, err error) {
+	if latStr == "" || lonStr == "" {
+		return 0, 0, fmt.Errorf("both --lat and --lon must be specified")
+	}
+	lat, err = strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --lat: %w", err)
+	}
+	lon, err = strconv.ParseFloat(lonStr, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --lon: %w", err)
+	}
+	return lat, lon, nil
+}
+
+// fetchAll fans out fetch across every named provider concurrently and
+// collects the observations that succeeded, logging a message for any
+// provider that failed or isn't registered. Each goroutine writes to
+// its own slot rather than appending under a lock, so the result
+// preserves the order of names regardless of which provider answers
+// first — callers that use obs[0] as a tie-breaker (e.g.
+// aggregate.Observation) get a deterministic pick instead of whichever
+// provider happened to finish first.
+func fetchAll(names []string, fetch func(providers.Provider) (providers.Observation, error)) []providers.Observation {
+	var wg sync.WaitGroup
+	slots := make([]providers.Observation, len(names))
+	ok := make([]bool, len(names))
+
+	for i, name := range names {
+		p, err := providers.Get(name)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, p providers.Provider) {
+			defer wg.Done()
+			data, err := fetch(p)
+			if err != nil {
+				fmt.Printf("%s: %s\n", p.Name(), explainError(err))
+				return
+			}
+			slots[i] = data
+			ok[i] = true
+		}(i, p)
+	}
+
+	wg.Wait()
+
+	obs := make([]providers.Observation, 0, len(names))
+	for i, got := range ok {
+		if got {
+			obs = append(obs, slots[i])
+		}
+	}
+	return obs
+}
+
+func fetchCoordinates(ctx context.Context, names []string, lat, lon float64, aggFn aggregate.Func) providers.Observation {
+	return aggregate.Observation(fetchCurrentByCoordinates(ctx, names, lat, lon), aggFn)
 }
 
-func loadCache(filename string) (*Cache, error) {
-    data, err := ioutil.ReadFile(filename)
-    if err != nil {
-        if os.IsNotExist(err) {
-            return nil, nil
-        }
-        return nil, err
-    }
-
-    var cache Cache
-    err = json.Unmarshal(data, &cache)
-    if err != nil {
-        return nil, err
-    }
-
-    return &cache, nil
+// runBatch resolves and fetches weather for every city concurrently,
+// bounded by a worker pool of size workers.
+func runBatch(ctx context.Context, names, cities []string, workers int, resolver *geocode.Resolver, aggFn aggregate.Func) []render.Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]render.Result, len(cities))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, query := range cities {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, query string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := render.Result{Query: query}
+
+			loc, err := resolver.Resolve(ctx, query)
+			if err != nil {
+				result.Err = err
+				results[i] = result
+				return
+			}
+			result.Location = loc
+
+			obs := fetchCoordinates(ctx, names, loc.Lat, loc.Lon, aggFn)
+			if obs.Provider == "" {
+				result.Err = fmt.Errorf("failed to retrieve weather data for %s", query)
+				results[i] = result
+				return
+			}
+			result.Observation = obs
+			results[i] = result
+		}(i, query)
+	}
+
+	wg.Wait()
+	return results
 }
 
-func saveCache(filename string, cache *Cache) error {
-    data, err := json.MarshalIndent(cache, "", "  ")
-    if err != nil {
-        return err
-    }
+func fetchCurrentByCoordinates(ctx context.Context, names []string, lat, lon float64) []providers.Observation {
+	return fetchAll(names, func(p providers.Provider) (providers.Observation, error) {
+		return p.CurrentByCoordinates(ctx, lat, lon)
+	})
+}
 
-    return ioutil.WriteFile(filename, data, 0644)
+func explainError(err error) string {
+	switch {
+	case errors.Is(err, httpx.ErrUnauthorized):
+		return "invalid or missing API key"
+	case errors.Is(err, httpx.ErrRateLimited):
+		return "rate limited, try again shortly"
+	case errors.Is(err, httpx.ErrCityNotFound):
+		return "city not found"
+	case errors.Is(err, httpx.ErrCircuitOpen):
+		return "too many recent failures, temporarily not retrying"
+	default:
+		return err.Error()
+	}
 }
 
-func getWeatherFromOpenWeatherMap(city, apiKey string) (WeatherData, error) {
-    url := fmt.Sprintf("http://api.openweathermap.org/data/2.5/weather?q=%s&appid=%s&units=metric", city, apiKey)
-    return fetchWeatherData(url)
+// fetchForecast fetches a forecast from every provider, one Result
+// per provider so each shows up as its own block/row regardless of
+// output format.
+func fetchForecast(ctx context.Context, names []string, city string, days int) []render.Result {
+	results := make([]render.Result, 0, len(names))
+	for _, name := range names {
+		p, err := providers.Get(name)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		query := fmt.Sprintf("%s (%s)", city, name)
+		dailies, err := p.Forecast(ctx, city, days)
+		if err != nil {
+			results = append(results, render.Result{Query: query, Err: err})
+			continue
+		}
+		results = append(results, render.Result{Query: query, Daily: dailies})
+	}
+	return results
 }
 
-func getWeatherFromWeatherAPI(city, apiKey string) (WeatherData, error) {
-    url := fmt.Sprintf("http://api.weatherapi.com/v1/current.json?key=%s&q=%s&aqi=no", apiKey, city)
-    return fetchWeatherData(url)
+// collectAlerts merges every provider's alerts into render.AlertEntry
+// values tagged with the provider that issued them. It always returns
+// a non-nil (possibly empty) slice, since an empty Alerts is how
+// render.Result distinguishes --mode=alerts with nothing active from
+// --mode=current.
+func collectAlerts(obs []providers.Observation) []render.AlertEntry {
+	alerts := make([]render.AlertEntry, 0)
+	for _, o := range obs {
+		for _, a := range o.Alerts {
+			alerts = append(alerts, render.AlertEntry{
+				Provider:    o.Provider,
+				Event:       a.Event,
+				Description: a.Description,
+				Start:       a.Start,
+				End:         a.End,
+			})
+		}
+	}
+	return alerts
 }
 
-func fetchWeatherData(url string) (WeatherData, error) {
-    resp, err := http.Get(url)
-    if err != nil {
-        return WeatherData{}, err
-    }
-    defer resp.Body.Close()
-
-    body, err := ioutil.ReadAll(resp.Body)
-    if err != nil {
-        return WeatherData{}, err
-    }
-
-    var weatherData WeatherData
-    err = json.Unmarshal(body, &weatherData)
-    if err != nil {
-        return WeatherData{}, err
-    }
-
-    return weatherData, nil
+func splitNonEmpty(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func loadConfig(filename string) (Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
 }
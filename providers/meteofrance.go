@@ -0,0 +1,28 @@
+package providers
+
+import "context"
+
+// MeteoFrance talks to the Météo-France public API. It is currently a
+// stub, see OpenMeteo for the rationale.
+type MeteoFrance struct{}
+
+// NewMeteoFrance builds and registers a Météo-France provider stub.
+func NewMeteoFrance() *MeteoFrance {
+	p := &MeteoFrance{}
+	Register(p)
+	return p
+}
+
+func (p *MeteoFrance) Name() string { return "meteofrance" }
+
+func (p *MeteoFrance) Current(ctx context.Context, city string) (Observation, error) {
+	return Observation{}, ErrNotImplemented
+}
+
+func (p *MeteoFrance) CurrentByCoordinates(ctx context.Context, lat, lon float64) (Observation, error) {
+	return Observation{}, ErrNotImplemented
+}
+
+func (p *MeteoFrance) Forecast(ctx context.Context, city string, days int) ([]Daily, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,36 @@
+package providers
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotImplemented is returned by provider stubs that are registered
+// but not yet wired up to a real backend.
+var ErrNotImplemented = errors.New("providers: not implemented")
+
+// OpenMeteo talks to api.open-meteo.com. It is currently a stub: it
+// registers under the "open-meteo" name so --providers accepts it,
+// and every call returns ErrNotImplemented.
+type OpenMeteo struct{}
+
+// NewOpenMeteo builds and registers an Open-Meteo provider stub.
+func NewOpenMeteo() *OpenMeteo {
+	p := &OpenMeteo{}
+	Register(p)
+	return p
+}
+
+func (p *OpenMeteo) Name() string { return "open-meteo" }
+
+func (p *OpenMeteo) Current(ctx context.Context, city string) (Observation, error) {
+	return Observation{}, ErrNotImplemented
+}
+
+func (p *OpenMeteo) CurrentByCoordinates(ctx context.Context, lat, lon float64) (Observation, error) {
+	return Observation{}, ErrNotImplemented
+}
+
+func (p *OpenMeteo) Forecast(ctx context.Context, city string, days int) ([]Daily, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,156 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Alyanaky/go_weather-client/httpx"
+)
+
+// OpenWeatherMap talks to api.openweathermap.org.
+type OpenWeatherMap struct {
+	APIKey string
+	client *httpx.Client
+}
+
+// NewOpenWeatherMap builds and registers an OpenWeatherMap provider.
+// It routes all requests through httpx with OWM's free-tier default
+// rate limit (60 requests/min).
+func NewOpenWeatherMap(apiKey string) *OpenWeatherMap {
+	p := &OpenWeatherMap{APIKey: apiKey, client: httpx.NewClient(httpx.DefaultConfig())}
+	Register(p)
+	return p
+}
+
+func (p *OpenWeatherMap) Name() string { return "owm" }
+
+type owmCurrentResponse struct {
+	Coord struct {
+		Lon float64 `json:"lon"`
+		Lat float64 `json:"lat"`
+	} `json:"coord"`
+	Weather []struct {
+		Description string `json:"description"`
+		Icon        string `json:"icon"`
+	} `json:"weather"`
+	Main struct {
+		Temp      float64 `json:"temp"`
+		FeelsLike float64 `json:"feels_like"`
+		Humidity  int     `json:"humidity"`
+		Pressure  float64 `json:"pressure"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+		Deg   float64 `json:"deg"`
+		Gust  float64 `json:"gust"`
+	} `json:"wind"`
+	Clouds struct {
+		All int `json:"all"`
+	} `json:"clouds"`
+	Visibility float64 `json:"visibility"`
+	Rain       struct {
+		OneHour float64 `json:"1h"`
+	} `json:"rain"`
+	Snow struct {
+		OneHour float64 `json:"1h"`
+	} `json:"snow"`
+	Sys struct {
+		Sunrise int64 `json:"sunrise"`
+		Sunset  int64 `json:"sunset"`
+	} `json:"sys"`
+	Name string `json:"name"`
+	Cod  int    `json:"cod"`
+}
+
+func (p *OpenWeatherMap) Current(ctx context.Context, city string) (Observation, error) {
+	endpoint := fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?q=%s&appid=%s&units=metric",
+		url.QueryEscape(city), url.QueryEscape(p.APIKey))
+	return p.current(ctx, endpoint, city)
+}
+
+func (p *OpenWeatherMap) CurrentByCoordinates(ctx context.Context, lat, lon float64) (Observation, error) {
+	endpoint := fmt.Sprintf("https://api.openweathermap.org/data/2.5/weather?lat=%f&lon=%f&appid=%s&units=metric",
+		lat, lon, url.QueryEscape(p.APIKey))
+	return p.current(ctx, endpoint, "")
+}
+
+func (p *OpenWeatherMap) current(ctx context.Context, endpoint, city string) (Observation, error) {
+	var resp owmCurrentResponse
+	if err := p.client.GetJSON(ctx, endpoint, &resp); err != nil {
+		return Observation{}, err
+	}
+
+	obs := Observation{
+		City:        city,
+		Provider:    p.Name(),
+		Coordinates: Coordinates{Lat: resp.Coord.Lat, Lon: resp.Coord.Lon},
+		TempC:       resp.Main.Temp,
+		FeelsLikeC:  resp.Main.FeelsLike,
+		Humidity:    resp.Main.Humidity,
+		PressureHPa: resp.Main.Pressure,
+		Wind: Wind{
+			SpeedMPS: resp.Wind.Speed,
+			DirDeg:   resp.Wind.Deg,
+			GustMPS:  resp.Wind.Gust,
+		},
+		CloudCover:  resp.Clouds.All,
+		Visibility:  resp.Visibility,
+		RainMM:      resp.Rain.OneHour,
+		SnowMM:      resp.Snow.OneHour,
+		SunriseUnix: resp.Sys.Sunrise,
+		SunsetUnix:  resp.Sys.Sunset,
+	}
+	if resp.Name != "" {
+		obs.City = resp.Name
+	}
+	if len(resp.Weather) > 0 {
+		obs.Description = resp.Weather[0].Description
+		obs.Icon = resp.Weather[0].Icon
+	}
+	return obs, nil
+}
+
+type owmForecastResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			TempMin  float64 `json:"temp_min"`
+			TempMax  float64 `json:"temp_max"`
+			Humidity int     `json:"humidity"`
+		} `json:"main"`
+		Weather []struct {
+			Description string `json:"description"`
+			Icon        string `json:"icon"`
+		} `json:"weather"`
+	} `json:"list"`
+}
+
+func (p *OpenWeatherMap) Forecast(ctx context.Context, city string, days int) ([]Daily, error) {
+	endpoint := fmt.Sprintf("https://api.openweathermap.org/data/2.5/forecast?q=%s&appid=%s&units=metric",
+		url.QueryEscape(city), url.QueryEscape(p.APIKey))
+
+	var resp owmForecastResponse
+	if err := p.client.GetJSON(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	// OWM's free forecast endpoint returns 3-hour steps; take one
+	// entry per day, capped at the requested number of days.
+	dailies := make([]Daily, 0, days)
+	for i := 0; i < len(resp.List) && len(dailies) < days; i += 8 {
+		entry := resp.List[i]
+		d := Daily{
+			DateUnix: entry.Dt,
+			TempMinC: entry.Main.TempMin,
+			TempMaxC: entry.Main.TempMax,
+			Humidity: entry.Main.Humidity,
+		}
+		if len(entry.Weather) > 0 {
+			d.Description = entry.Weather[0].Description
+			d.Icon = entry.Weather[0].Icon
+		}
+		dailies = append(dailies, d)
+	}
+	return dailies, nil
+}
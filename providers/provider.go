@@ -0,0 +1,104 @@
+// Package providers defines the common weather data model and the
+// Provider interface implemented by each backend (OpenWeatherMap,
+// WeatherAPI, Open-Meteo, Météo-France, ...).
+package providers
+
+import (
+	"context"
+	"fmt"
+)
+
+// Coordinates is a simple lat/lon pair.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Wind describes wind speed, direction and gusts.
+type Wind struct {
+	SpeedMPS float64 `json:"speed_mps"`
+	DirDeg   float64 `json:"dir_deg"`
+	GustMPS  float64 `json:"gust_mps,omitempty"`
+}
+
+// Alert is a provider-issued weather alert/warning.
+type Alert struct {
+	SenderName  string `json:"sender_name"`
+	Event       string `json:"event"`
+	Description string `json:"description"`
+	Start       int64  `json:"start"`
+	End         int64  `json:"end"`
+}
+
+// Observation is the common shape every provider maps its native JSON
+// response into. Fields that a given provider doesn't expose are left
+// at their zero value.
+type Observation struct {
+	City        string      `json:"city"`
+	Provider    string      `json:"provider"`
+	Coordinates Coordinates `json:"coordinates"`
+
+	TempC       float64 `json:"temp_c"`
+	FeelsLikeC  float64 `json:"feels_like_c"`
+	Humidity    int     `json:"humidity"`
+	PressureHPa float64 `json:"pressure_hpa"`
+
+	Wind       Wind    `json:"wind"`
+	CloudCover int     `json:"cloud_cover_percent"`
+	Visibility float64 `json:"visibility_m"`
+
+	RainMM float64 `json:"rain_mm"`
+	SnowMM float64 `json:"snow_mm"`
+
+	SunriseUnix int64 `json:"sunrise_unix"`
+	SunsetUnix  int64 `json:"sunset_unix"`
+
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+	Alerts      []Alert `json:"alerts,omitempty"`
+}
+
+// Daily is a single day of a multi-day forecast.
+type Daily struct {
+	DateUnix    int64   `json:"date_unix"`
+	TempMinC    float64 `json:"temp_min_c"`
+	TempMaxC    float64 `json:"temp_max_c"`
+	Humidity    int     `json:"humidity"`
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+}
+
+// Provider is implemented by each weather backend.
+type Provider interface {
+	// Name is the short identifier used on the command line, e.g. "owm".
+	Name() string
+	Current(ctx context.Context, city string) (Observation, error)
+	CurrentByCoordinates(ctx context.Context, lat, lon float64) (Observation, error)
+	Forecast(ctx context.Context, city string, days int) ([]Daily, error)
+}
+
+var registry = map[string]Provider{}
+
+// Register adds a provider to the registry under its Name(). It is
+// intended to be called from provider constructors at startup.
+func Register(p Provider) {
+	registry[p.Name()] = p
+}
+
+// Get looks up a registered provider by name.
+func Get(name string) (Provider, error) {
+	p, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("providers: unknown provider %q", name)
+	}
+	return p, nil
+}
+
+// Names returns the names of all registered providers.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,207 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Alyanaky/go_weather-client/httpx"
+)
+
+// WeatherAPI talks to api.weatherapi.com.
+type WeatherAPI struct {
+	APIKey string
+	client *httpx.Client
+}
+
+// NewWeatherAPI builds and registers a WeatherAPI provider, routing
+// all requests through httpx with a conservative default rate limit.
+func NewWeatherAPI(apiKey string) *WeatherAPI {
+	p := &WeatherAPI{APIKey: apiKey, client: httpx.NewClient(httpx.DefaultConfig())}
+	Register(p)
+	return p
+}
+
+func (p *WeatherAPI) Name() string { return "weatherapi" }
+
+type weatherAPICurrentResponse struct {
+	Location struct {
+		Name string  `json:"name"`
+		Lat  float64 `json:"lat"`
+		Lon  float64 `json:"lon"`
+	} `json:"location"`
+	Current struct {
+		TempC      float64 `json:"temp_c"`
+		FeelsLike  float64 `json:"feelslike_c"`
+		Humidity   int     `json:"humidity"`
+		PressureMB float64 `json:"pressure_mb"`
+		WindKPH    float64 `json:"wind_kph"`
+		WindDegree float64 `json:"wind_degree"`
+		GustKPH    float64 `json:"gust_kph"`
+		CloudCover int     `json:"cloud"`
+		VisKM      float64 `json:"vis_km"`
+		PrecipMM   float64 `json:"precip_mm"`
+		IsDay      int     `json:"is_day"`
+		Condition  struct {
+			Text string `json:"text"`
+			Icon string `json:"icon"`
+			Code int    `json:"code"`
+		} `json:"condition"`
+	} `json:"current"`
+	Alerts struct {
+		Alert []struct {
+			Event      string `json:"event"`
+			Desc       string `json:"desc"`
+			Effective  string `json:"effective"`
+			Expires    string `json:"expires"`
+			SenderName string `json:"sender_name"`
+		} `json:"alert"`
+	} `json:"alerts"`
+}
+
+func (p *WeatherAPI) Current(ctx context.Context, city string) (Observation, error) {
+	return p.current(ctx, city, city)
+}
+
+// CurrentByCoordinates uses WeatherAPI's support for "lat,lon" as the
+// q parameter directly, so no separate endpoint is needed.
+func (p *WeatherAPI) CurrentByCoordinates(ctx context.Context, lat, lon float64) (Observation, error) {
+	return p.current(ctx, fmt.Sprintf("%f,%f", lat, lon), "")
+}
+
+// current fetches by way of the forecast.json endpoint rather than
+// current.json: forecast.json returns the same "current" block plus
+// an "alerts" block when alerts=yes is set, so a single request gives
+// us both current conditions and any active alerts.
+func (p *WeatherAPI) current(ctx context.Context, query, city string) (Observation, error) {
+	endpoint := fmt.Sprintf("https://api.weatherapi.com/v1/forecast.json?key=%s&q=%s&days=1&aqi=no&alerts=yes",
+		url.QueryEscape(p.APIKey), url.QueryEscape(query))
+
+	var resp weatherAPICurrentResponse
+	if err := p.client.GetJSON(ctx, endpoint, &resp); err != nil {
+		return Observation{}, err
+	}
+
+	obs := Observation{
+		City:        city,
+		Provider:    p.Name(),
+		Coordinates: Coordinates{Lat: resp.Location.Lat, Lon: resp.Location.Lon},
+		TempC:       resp.Current.TempC,
+		FeelsLikeC:  resp.Current.FeelsLike,
+		Humidity:    resp.Current.Humidity,
+		PressureHPa: resp.Current.PressureMB,
+		Wind: Wind{
+			SpeedMPS: resp.Current.WindKPH / 3.6,
+			DirDeg:   resp.Current.WindDegree,
+			GustMPS:  resp.Current.GustKPH / 3.6,
+		},
+		CloudCover:  resp.Current.CloudCover,
+		Visibility:  resp.Current.VisKM * 1000,
+		RainMM:      resp.Current.PrecipMM,
+		Description: resp.Current.Condition.Text,
+		Icon:        weatherAPIIcon(resp.Current.Condition.Code, resp.Current.IsDay == 1),
+	}
+	if resp.Location.Name != "" {
+		obs.City = resp.Location.Name
+	}
+
+	for _, a := range resp.Alerts.Alert {
+		obs.Alerts = append(obs.Alerts, Alert{
+			SenderName:  a.SenderName,
+			Event:       a.Event,
+			Description: a.Desc,
+			Start:       parseWeatherAPITime(a.Effective),
+			End:         parseWeatherAPITime(a.Expires),
+		})
+	}
+
+	return obs, nil
+}
+
+// weatherAPIIcon translates a WeatherAPI condition code (see
+// https://www.weatherapi.com/docs/weather_conditions.json) into the
+// OpenWeatherMap-style icon code render.Glyph understands, so the two
+// providers' icons agree instead of WeatherAPI's CDN-URL icon field
+// falling back to a generic sun.
+func weatherAPIIcon(code int, isDay bool) string {
+	suffix := "d"
+	if !isDay {
+		suffix = "n"
+	}
+
+	switch {
+	case code == 1000:
+		return "01" + suffix
+	case code == 1003:
+		return "02" + suffix
+	case code == 1006:
+		return "03" + suffix
+	case code == 1009:
+		return "04" + suffix
+	case code == 1030 || code == 1135 || code == 1147:
+		return "50" + suffix
+	case code == 1087 || (code >= 1273 && code <= 1282):
+		return "11" + suffix
+	case (code >= 1210 && code <= 1225) || (code >= 1255 && code <= 1264) || (code >= 1114 && code <= 1117):
+		return "13" + suffix
+	case (code >= 1063 && code <= 1201) || (code >= 1240 && code <= 1246):
+		return "09" + suffix
+	default:
+		return "01" + suffix
+	}
+}
+
+// parseWeatherAPITime parses WeatherAPI's "2006-01-02 15:04" alert
+// timestamps, returning 0 if the field is empty or malformed.
+func parseWeatherAPITime(s string) int64 {
+	if s == "" {
+		return 0
+	}
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+type weatherAPIForecastResponse struct {
+	Forecast struct {
+		Forecastday []struct {
+			DateEpoch int64 `json:"date_epoch"`
+			Day       struct {
+				MinTempC    float64 `json:"mintemp_c"`
+				MaxTempC    float64 `json:"maxtemp_c"`
+				AvgHumidity float64 `json:"avghumidity"`
+				Condition   struct {
+					Text string `json:"text"`
+					Icon string `json:"icon"`
+					Code int    `json:"code"`
+				} `json:"condition"`
+			} `json:"day"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+func (p *WeatherAPI) Forecast(ctx context.Context, city string, days int) ([]Daily, error) {
+	endpoint := fmt.Sprintf("https://api.weatherapi.com/v1/forecast.json?key=%s&q=%s&days=%d&aqi=no&alerts=no",
+		url.QueryEscape(p.APIKey), url.QueryEscape(city), days)
+
+	var resp weatherAPIForecastResponse
+	if err := p.client.GetJSON(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	dailies := make([]Daily, 0, len(resp.Forecast.Forecastday))
+	for _, fd := range resp.Forecast.Forecastday {
+		dailies = append(dailies, Daily{
+			DateUnix:    fd.DateEpoch,
+			TempMinC:    fd.Day.MinTempC,
+			TempMaxC:    fd.Day.MaxTempC,
+			Humidity:    int(fd.Day.AvgHumidity),
+			Description: fd.Day.Condition.Text,
+			Icon:        weatherAPIIcon(fd.Day.Condition.Code, true),
+		})
+	}
+	return dailies, nil
+}
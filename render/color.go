@@ -0,0 +1,52 @@
+package render
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	ansiBlue   = "\x1b[34m"
+	ansiCyan   = "\x1b[36m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiRed    = "\x1b[31m"
+	ansiReset  = "\x1b[0m"
+)
+
+// ColorEnabled reports whether color output should be used by
+// default: respecting --no-color (noColorFlag) and the NO_COLOR
+// convention (https://no-color.org).
+func ColorEnabled(noColorFlag bool) bool {
+	if noColorFlag {
+		return false
+	}
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return false
+	}
+	return true
+}
+
+// colorForTemp buckets a Celsius temperature into a color: blue for
+// cold, cyan for cool, green for mild, yellow for warm, red for hot.
+func colorForTemp(tempC float64) string {
+	switch {
+	case tempC < 0:
+		return ansiBlue
+	case tempC < 10:
+		return ansiCyan
+	case tempC < 20:
+		return ansiGreen
+	case tempC < 30:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+func colorize(enabled bool, color, text string) string {
+	if !enabled {
+		return text
+	}
+	return fmt.Sprintf("%s%s%s", color, text, ansiReset)
+}
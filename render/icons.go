@@ -0,0 +1,32 @@
+package render
+
+import "strings"
+
+// icons maps OpenWeatherMap icon codes (e.g. "01d", "10n") to a
+// Unicode glyph. The last character (d/n) selects day or night where
+// it matters; unknown codes fall back to a moon for night ("n") codes
+// and a plain sun otherwise.
+var icons = map[string]string{
+	"01d": "☀️", "01n": "🌙",
+	"02d": "⛅", "02n": "☁️",
+	"03d": "☁️", "03n": "☁️",
+	"04d": "☁️", "04n": "☁️",
+	"09d": "🌧️", "09n": "🌧️",
+	"10d": "🌦️", "10n": "🌧️",
+	"11d": "⛈️", "11n": "⛈️",
+	"13d": "❄️", "13n": "❄️",
+	"50d": "🌫️", "50n": "🌫️",
+}
+
+// Glyph returns the Unicode/emoji glyph for an OWM-style icon code. An
+// unrecognized code gets a moon if it ends in "n" and a generic sun
+// otherwise.
+func Glyph(code string) string {
+	if glyph, ok := icons[code]; ok {
+		return glyph
+	}
+	if strings.HasSuffix(code, "n") {
+		return "🌙"
+	}
+	return "☀️"
+}
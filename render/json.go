@@ -0,0 +1,44 @@
+package render
+
+import "encoding/json"
+
+// JSONFormatter emits one JSON object per city, each carrying every
+// field of the aggregated Observation.
+type JSONFormatter struct{}
+
+type jsonResult struct {
+	City     string       `json:"city" yaml:"city"`
+	Error    string       `json:"error,omitempty" yaml:"error,omitempty"`
+	Obs      interface{}  `json:"observation,omitempty" yaml:"observation,omitempty"`
+	Forecast interface{}  `json:"forecast,omitempty" yaml:"forecast,omitempty"`
+	Alerts   []AlertEntry `json:"alerts,omitempty" yaml:"alerts,omitempty"`
+}
+
+func toJSONResults(results []Result) []jsonResult {
+	out := make([]jsonResult, 0, len(results))
+	for _, r := range results {
+		jr := jsonResult{City: displayName(r)}
+		switch {
+		case r.Err != nil:
+			jr.Error = r.Err.Error()
+		case r.Daily != nil:
+			jr.Forecast = r.Daily
+		case r.Alerts != nil:
+			jr.Alerts = r.Alerts
+		default:
+			jr.Obs = r.Observation
+		}
+		out = append(out, jr)
+	}
+	return out
+}
+
+func (f *JSONFormatter) Format(results []Result) (string, error) {
+	out := toJSONResults(results)
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
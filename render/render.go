@@ -0,0 +1,59 @@
+// Package render turns weather data into user-facing output: the
+// original pretty-printed text, JSON/YAML for scripts, and an aligned
+// table for multi-city results.
+package render
+
+import (
+	"fmt"
+
+	"github.com/Alyanaky/go_weather-client/geocode"
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// Result is one city's worth of output: its resolved location (when
+// known), plus exactly one of Observation (--mode=current), Daily
+// (--mode=forecast) or Alerts (--mode=alerts, possibly empty), or Err
+// if the lookup failed.
+type Result struct {
+	Query       string
+	Location    geocode.Location
+	Observation providers.Observation
+	Daily       []providers.Daily
+	Alerts      []AlertEntry
+	FromCache   bool
+	Err         error
+}
+
+// AlertEntry pairs a weather alert with the provider that issued it,
+// since a single Result can carry alerts merged from several
+// providers.
+type AlertEntry struct {
+	Provider    string `json:"provider" yaml:"provider"`
+	Event       string `json:"event" yaml:"event"`
+	Description string `json:"description" yaml:"description"`
+	Start       int64  `json:"start" yaml:"start"`
+	End         int64  `json:"end" yaml:"end"`
+}
+
+// Formatter renders a set of Results as a string.
+type Formatter interface {
+	Format(results []Result) (string, error)
+}
+
+// New builds the Formatter named by --output (text, json, yaml,
+// table). color controls whether the text formatter emits ANSI color
+// codes.
+func New(name string, color bool) (Formatter, error) {
+	switch name {
+	case "", "text":
+		return &TextFormatter{Color: color}, nil
+	case "json":
+		return &JSONFormatter{}, nil
+	case "yaml":
+		return &YAMLFormatter{}, nil
+	case "table":
+		return &TableFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("render: unknown output format %q", name)
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// TableFormatter prints one aligned row per city, for --cities and
+// --cities-file output.
+type TableFormatter struct{}
+
+func (f *TableFormatter) Format(results []Result) (string, error) {
+	// Forecasts and alerts aren't tabular data (a day-by-day series
+	// and a variable-length alert list, respectively), so they're
+	// rendered the same way as TextFormatter rather than forced into
+	// the CITY/TEMP/HUMIDITY grid below.
+	var prose strings.Builder
+	var rows []Result
+	for _, r := range results {
+		switch {
+		case r.Err == nil && r.Daily != nil:
+			writeForecastText(&prose, r)
+		case r.Err == nil && r.Alerts != nil:
+			writeAlertsText(&prose, r)
+		default:
+			rows = append(rows, r)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(prose.String())
+
+	if len(rows) > 0 {
+		w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+		writeRow(w, "CITY", "COUNTRY", "LAT", "LON", "TEMP (C)", "HUMIDITY", "DESCRIPTION")
+
+		for _, r := range rows {
+			if r.Err != nil {
+				writeRow(w, r.Query, "ERROR: "+r.Err.Error(), "", "", "", "", "")
+				continue
+			}
+
+			obs := r.Observation
+			writeRow(w,
+				displayName(r), r.Location.Country,
+				formatFloat(r.Location.Lat), formatFloat(r.Location.Lon),
+				formatFloat(obs.TempC), formatPercent(obs.Humidity), obs.Description)
+		}
+
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+
+	return b.String(), nil
+}
+
+func writeRow(w io.Writer, cols ...string) {
+	fmt.Fprintln(w, strings.Join(cols, "\t"))
+}
+
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%.2f", f)
+}
+
+func formatPercent(n int) string {
+	return fmt.Sprintf("%d%%", n)
+}
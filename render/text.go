@@ -0,0 +1,82 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TextFormatter reproduces the original pretty-printed CLI output,
+// with a weather icon and, optionally, color-coded temperature.
+type TextFormatter struct {
+	Color bool
+}
+
+func (f *TextFormatter) Format(results []Result) (string, error) {
+	var b strings.Builder
+
+	for i, r := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		if r.Err != nil {
+			fmt.Fprintf(&b, "%s: %v\n", displayName(r), r.Err)
+			continue
+		}
+
+		if r.Daily != nil {
+			writeForecastText(&b, r)
+			continue
+		}
+
+		if r.Alerts != nil {
+			writeAlertsText(&b, r)
+			continue
+		}
+
+		obs := r.Observation
+		suffix := ""
+		if r.FromCache {
+			suffix = " (from cache)"
+		}
+
+		temp := fmt.Sprintf("%.2f°C", obs.TempC)
+		fmt.Fprintf(&b, "%s %s%s\n", Glyph(obs.Icon), displayName(r), suffix)
+		fmt.Fprintf(&b, "Temperature: %s (feels like %.2f°C)\n", colorize(f.Color, colorForTemp(obs.TempC), temp), obs.FeelsLikeC)
+		fmt.Fprintf(&b, "Humidity: %d%%\n", obs.Humidity)
+		fmt.Fprintf(&b, "Pressure: %.0f hPa\n", obs.PressureHPa)
+		fmt.Fprintf(&b, "Wind: %.1f m/s at %.0f°\n", obs.Wind.SpeedMPS, obs.Wind.DirDeg)
+		fmt.Fprintf(&b, "Cloud cover: %d%%\n", obs.CloudCover)
+		if obs.Description != "" {
+			fmt.Fprintf(&b, "Description: %s\n", obs.Description)
+		}
+	}
+
+	return b.String(), nil
+}
+
+func writeForecastText(b *strings.Builder, r Result) {
+	fmt.Fprintf(b, "Forecast for %s:\n", displayName(r))
+	for _, d := range r.Daily {
+		fmt.Fprintf(b, "  %s: %.1f°C - %.1f°C, %s\n",
+			time.Unix(d.DateUnix, 0).Format("2006-01-02"), d.TempMinC, d.TempMaxC, d.Description)
+	}
+}
+
+func writeAlertsText(b *strings.Builder, r Result) {
+	if len(r.Alerts) == 0 {
+		fmt.Fprintln(b, "No active alerts")
+		return
+	}
+	for _, a := range r.Alerts {
+		fmt.Fprintf(b, "[%s] %s: %s\n", a.Provider, a.Event, a.Description)
+	}
+}
+
+func displayName(r Result) string {
+	if r.Observation.City != "" {
+		return r.Observation.City
+	}
+	return r.Query
+}
@@ -0,0 +1,16 @@
+package render
+
+import "gopkg.in/yaml.v3"
+
+// YAMLFormatter emits the same data as JSONFormatter, as YAML.
+type YAMLFormatter struct{}
+
+func (f *YAMLFormatter) Format(results []Result) (string, error) {
+	out := toJSONResults(results)
+
+	data, err := yaml.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
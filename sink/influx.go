@@ -0,0 +1,82 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// InfluxConfig configures an InfluxDB line-protocol sink.
+type InfluxConfig struct {
+	URL         string `yaml:"url"`
+	Database    string `yaml:"database"`
+	Measurement string `yaml:"measurement"`
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+}
+
+// Influx writes observations to InfluxDB's /write endpoint using the
+// line protocol, over a shared *http.Client.
+type Influx struct {
+	cfg    InfluxConfig
+	client *http.Client
+}
+
+// NewInflux builds an Influx sink that writes over client, which
+// callers should share across sinks and providers to keep connection
+// pooling effective.
+func NewInflux(cfg InfluxConfig, client *http.Client) *Influx {
+	if cfg.Measurement == "" {
+		cfg.Measurement = "weather"
+	}
+	return &Influx{cfg: cfg, client: client}
+}
+
+func (s *Influx) Write(ctx context.Context, obs providers.Observation) error {
+	line := lineProtocol(s.cfg.Measurement, obs)
+
+	endpoint := strings.TrimSuffix(s.cfg.URL, "/") + "/write?" + url.Values{
+		"db": {s.cfg.Database},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(line))
+	if err != nil {
+		return err
+	}
+	if s.cfg.Username != "" {
+		req.SetBasicAuth(s.cfg.Username, s.cfg.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink: influxdb write failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *Influx) Close() error { return nil }
+
+func lineProtocol(measurement string, obs providers.Observation) string {
+	tags := fmt.Sprintf("city=%s,provider=%s", escapeTag(obs.City), escapeTag(obs.Provider))
+	fields := fmt.Sprintf(
+		"temp_c=%f,feels_like_c=%f,humidity=%di,pressure_hpa=%f,wind_speed_mps=%f",
+		obs.TempC, obs.FeelsLikeC, obs.Humidity, obs.PressureHPa, obs.Wind.SpeedMPS,
+	)
+	return fmt.Sprintf("%s,%s %s %d\n", measurement, tags, fields, time.Now().UnixNano())
+}
+
+func escapeTag(s string) string {
+	s = strings.ReplaceAll(s, " ", "\\ ")
+	s = strings.ReplaceAll(s, ",", "\\,")
+	return s
+}
@@ -0,0 +1,74 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// PrometheusConfig configures the Prometheus exposition sink.
+type PrometheusConfig struct {
+	ListenAddr string `yaml:"listen_addr"`
+}
+
+// Prometheus exposes the latest observation per city/provider as
+// gauges on a /metrics HTTP endpoint.
+type Prometheus struct {
+	server *http.Server
+
+	temperature *prometheus.GaugeVec
+	humidity    *prometheus.GaugeVec
+	windSpeed   *prometheus.GaugeVec
+}
+
+// NewPrometheus builds a Prometheus sink and starts its HTTP server in
+// the background. Call Close to shut the server down.
+func NewPrometheus(cfg PrometheusConfig) *Prometheus {
+	s := &Prometheus{
+		temperature: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_temperature_celsius",
+			Help: "Current temperature in Celsius.",
+		}, []string{"city", "provider"}),
+		humidity: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_humidity_percent",
+			Help: "Current relative humidity in percent.",
+		}, []string{"city", "provider"}),
+		windSpeed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "weather_wind_speed_mps",
+			Help: "Current wind speed in meters per second.",
+		}, []string{"city", "provider"}),
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s.temperature, s.humidity, s.windSpeed)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	s.server = &http.Server{Addr: cfg.ListenAddr, Handler: mux}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Println("sink: prometheus metrics server failed:", err)
+		}
+	}()
+
+	return s
+}
+
+func (s *Prometheus) Write(ctx context.Context, obs providers.Observation) error {
+	labels := prometheus.Labels{"city": obs.City, "provider": obs.Provider}
+	s.temperature.With(labels).Set(obs.TempC)
+	s.humidity.With(labels).Set(float64(obs.Humidity))
+	s.windSpeed.With(labels).Set(obs.Wind.SpeedMPS)
+	return nil
+}
+
+func (s *Prometheus) Close() error {
+	return s.server.Close()
+}
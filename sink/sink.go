@@ -0,0 +1,15 @@
+// Package sink defines where the daemon pushes the observations it
+// polls, and ships InfluxDB and Prometheus implementations.
+package sink
+
+import (
+	"context"
+
+	"github.com/Alyanaky/go_weather-client/providers"
+)
+
+// Sink receives observations as the daemon polls them.
+type Sink interface {
+	Write(ctx context.Context, obs providers.Observation) error
+	Close() error
+}